@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/pipeline"
+)
+
+var listAdaptorsCmd = &cobra.Command{
+	Use:   "list-adaptors",
+	Short: "List registered source, sink, and transformer adaptors",
+	RunE:  listAdaptors,
+}
+
+func init() {
+	rootCmd.AddCommand(listAdaptorsCmd)
+}
+
+func listAdaptors(cmd *cobra.Command, args []string) error {
+	for _, adaptor := range pipeline.DescribeAdaptors() {
+		fmt.Printf("%-12s %s\n", adaptor.Kind, adaptor.Name)
+	}
+	return nil
+}
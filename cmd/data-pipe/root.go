@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/config"
+)
+
+// configPath is shared by every subcommand that loads a configuration.
+var configPath string
+
+var rootCmd = &cobra.Command{
+	Use:   "data-pipe",
+	Short: "A configurable CDC pipeline between sources and sinks",
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "config.json", "Path to configuration file (JSON or YAML)")
+}
+
+// loadConfig builds the layered configuration for a command: defaults, the
+// config file, environment variables (DATAPIPE_*), then any flags bound on
+// cmd by the caller, in increasing order of precedence.
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	v, err := config.NewViper(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.BindPFlags(cmd.Flags()); err != nil {
+		return nil, err
+	}
+
+	return config.FromViper(v)
+}
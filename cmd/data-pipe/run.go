@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/logging"
+	"github.com/IEatCodeDaily/data-pipe/pkg/pipeline"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the pipeline",
+	RunE:  runPipeline,
+}
+
+func init() {
+	// Common overrides, mapped directly onto their config keys so that
+	// BindPFlags in loadConfig wires them up automatically. Anything not
+	// covered here can still be set via the config file or a DATAPIPE_ env
+	// var.
+	runCmd.Flags().String("source.type", "", "Source adaptor type (overrides config file)")
+	runCmd.Flags().String("source.settings.uri", "", "Source connection URI (overrides config file)")
+	runCmd.Flags().String("sink.type", "", "Sink adaptor type (overrides config file)")
+	runCmd.Flags().String("sink.settings.connection_string", "", "Sink connection string (overrides config file)")
+	runCmd.Flags().String("logging.format", "text", "Log output format: text or json (overrides config file)")
+	runCmd.Flags().String("logging.level", "info", "Minimum log level: debug, info, warn, or error (overrides config file)")
+
+	rootCmd.AddCommand(runCmd)
+}
+
+func runPipeline(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	logger, err := newLogger(cfg.Logging.Format, cfg.Logging.Level)
+	if err != nil {
+		return err
+	}
+	logger = logger.With("pipeline", cfg.Pipeline.Name)
+	logger.Info("loaded configuration")
+
+	// The mongodb source's resume store is keyed by pipeline name; thread it
+	// through via settings since adaptor factories only see their own config.
+	if cfg.Source.Settings == nil {
+		cfg.Source.Settings = map[string]interface{}{}
+	}
+	cfg.Source.Settings["pipeline_name"] = cfg.Pipeline.Name
+
+	src, err := pipeline.NewSource(cfg.Source.Type, cfg.Source, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create source: %w", err)
+	}
+
+	snk, err := pipeline.NewSink(cfg.Sink.Type, cfg.Sink, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create sink: %w", err)
+	}
+
+	transformerType := cfg.Transformer.Type
+	if transformerType == "" {
+		transformerType = "passthrough"
+	}
+	transformer, err := pipeline.NewTransformer(transformerType, cfg.Transformer)
+	if err != nil {
+		return fmt.Errorf("failed to create transformer: %w", err)
+	}
+
+	pipe := pipeline.New(cfg.Pipeline.Name, src, snk, transformer, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+		logger.Info("received shutdown signal, stopping pipeline")
+		cancel()
+	}()
+
+	logger.Info("starting data pipeline")
+	if err := pipe.Run(ctx); err != nil {
+		return fmt.Errorf("pipeline error: %w", err)
+	}
+
+	logger.Info("pipeline stopped")
+	return nil
+}
+
+// newLogger builds the root slog.Logger for the run command, wrapping it
+// with duplicate suppression since a flapping change stream can otherwise
+// repeat the same error thousands of times.
+func newLogger(format, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log-level %q: %w", level, err)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	var handler slog.Handler
+	switch format {
+	case "json":
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	case "text":
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	default:
+		return nil, fmt.Errorf("invalid log-format %q: must be \"text\" or \"json\"", format)
+	}
+
+	return slog.New(logging.NewDedupeHandler(handler)), nil
+}
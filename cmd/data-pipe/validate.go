@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the configuration without starting the pipeline",
+	RunE:  validateConfig,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+func validateConfig(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	fmt.Printf("OK: pipeline %q (%s -> %s)\n", cfg.Pipeline.Name, cfg.Source.Type, cfg.Sink.Type)
+	return nil
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// Version is the data-pipe release version, set via -ldflags at build time.
+var Version = "dev"
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the data-pipe version",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println(Version)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+}
@@ -4,13 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+
+	"github.com/spf13/viper"
 )
 
 // Config represents the pipeline configuration
 type Config struct {
-	Pipeline PipelineConfig `json:"pipeline"`
-	Source   SourceConfig   `json:"source"`
-	Sink     SinkConfig     `json:"sink"`
+	Pipeline    PipelineConfig    `json:"pipeline"`
+	Source      SourceConfig      `json:"source"`
+	Sink        SinkConfig        `json:"sink"`
+	Transformer TransformerConfig `json:"transformer"`
+	Logging     LoggingConfig     `json:"logging"`
 }
 
 // PipelineConfig contains pipeline-level settings
@@ -18,6 +23,12 @@ type PipelineConfig struct {
 	Name string `json:"name"`
 }
 
+// LoggingConfig contains settings for the root slog.Logger.
+type LoggingConfig struct {
+	Format string `json:"format"` // "text" or "json"
+	Level  string `json:"level"`  // "debug", "info", "warn", or "error"
+}
+
 // SourceConfig contains source configuration
 type SourceConfig struct {
 	Type     string                 `json:"type"` // mongodb, convex, etc.
@@ -30,21 +41,87 @@ type SinkConfig struct {
 	Settings map[string]interface{} `json:"settings"`
 }
 
-// LoadFromFile loads configuration from a JSON file
+// TransformerConfig contains transformer configuration
+type TransformerConfig struct {
+	Type     string                 `json:"type"` // fieldmapper, passthrough, etc.
+	Settings map[string]interface{} `json:"settings"`
+}
+
+// EnvPrefix is the prefix used for environment variable overrides, e.g.
+// DATAPIPE_SOURCE_SETTINGS_URI overrides the source.settings.uri setting.
+const EnvPrefix = "DATAPIPE"
+
+// LoadFromFile loads configuration from a file, preserving the original
+// JSON-only schema. It is now a thin wrapper over viper so that JSON and
+// YAML files both work. Environment overrides apply here too, since it goes
+// through NewViper; callers that also want flag overrides should build a
+// *viper.Viper with NewViper, bind their flags, and call FromViper directly.
 func LoadFromFile(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
+	v, err := NewViper(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, err
+	}
+	return FromViper(v)
+}
+
+// NewViper builds a viper instance that layers, from lowest to highest
+// precedence: the config file at path and environment
+// variables prefixed with EnvPrefix (e.g. DATAPIPE_SOURCE_SETTINGS_URI maps
+// to source.settings.uri, the path SourceConfig actually unmarshals from).
+// Callers that also accept CLI flags should bind them with v.BindPFlag after
+// calling this, since bound flags take precedence over everything here.
+func NewViper(path string) (*viper.Viper, error) {
+	v := viper.New()
+
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	if path != "" {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("failed to read config file: %w", err)
+			}
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+
+	return v, nil
+}
+
+// FromViper decodes a *viper.Viper into a Config. It goes through
+// encoding/json rather than viper's own mapstructure decoding so that the
+// json struct tags above remain the single source of truth for key names.
+func FromViper(v *viper.Viper) (*Config, error) {
+	data, err := json.Marshal(v.AllSettings())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal configuration: %w", err)
 	}
 
 	var config Config
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
 	}
 
 	return &config, nil
 }
 
+// Validate checks that the configuration has the minimum fields required to
+// build a pipeline.
+func (c *Config) Validate() error {
+	if c.Pipeline.Name == "" {
+		return fmt.Errorf("pipeline.name is required")
+	}
+	if c.Source.Type == "" {
+		return fmt.Errorf("source.type is required")
+	}
+	if c.Sink.Type == "" {
+		return fmt.Errorf("sink.type is required")
+	}
+	return nil
+}
+
 // GetString safely retrieves a string from settings
 func (s SourceConfig) GetString(key string) string {
 	if val, ok := s.Settings[key].(string); ok {
@@ -53,6 +130,22 @@ func (s SourceConfig) GetString(key string) string {
 	return ""
 }
 
+// GetStringSlice safely retrieves a string slice from settings
+func (s SourceConfig) GetStringSlice(key string) []string {
+	return getStringSlice(s.Settings, key)
+}
+
+// GetInt safely retrieves an int from settings
+func (s SourceConfig) GetInt(key string) int {
+	return getInt(s.Settings, key)
+}
+
+// GetBool safely retrieves a bool from settings
+func (s SourceConfig) GetBool(key string) bool {
+	val, _ := s.Settings[key].(bool)
+	return val
+}
+
 // GetString safely retrieves a string from settings
 func (s SinkConfig) GetString(key string) string {
 	if val, ok := s.Settings[key].(string); ok {
@@ -60,3 +153,60 @@ func (s SinkConfig) GetString(key string) string {
 	}
 	return ""
 }
+
+// GetStringSlice safely retrieves a string slice from settings
+func (s SinkConfig) GetStringSlice(key string) []string {
+	return getStringSlice(s.Settings, key)
+}
+
+// TableForCollection resolves the destination table for an event's source
+// collection, so a single sink can fan out writes from a multi-collection
+// source (e.g. MongoDBSource with a regex collection pattern) to different
+// tables. It consults the "table_mapping" settings object, keyed by
+// collection name, and falls back to the sink's default "table" setting
+// when the collection has no explicit mapping.
+func (s SinkConfig) TableForCollection(collection string) string {
+	if mapping, ok := s.Settings["table_mapping"].(map[string]interface{}); ok {
+		if table, ok := mapping[collection].(string); ok && table != "" {
+			return table
+		}
+	}
+	return s.GetString("table")
+}
+
+// getInt pulls an int out of a settings map, tolerating the float64 shape
+// produced by encoding/json.
+func getInt(settings map[string]interface{}, key string) int {
+	switch v := settings[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// getStringSlice pulls a []string out of a settings map, tolerating the
+// []interface{} shape produced by encoding/json.
+func getStringSlice(settings map[string]interface{}, key string) []string {
+	raw, ok := settings[key]
+	if !ok {
+		return nil
+	}
+
+	switch v := raw.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
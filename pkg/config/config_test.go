@@ -0,0 +1,114 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestNestedSettingsFlagOverridesConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	fileConfig := `{"pipeline":{"name":"p"},"source":{"type":"mongodb","settings":{"uri":"mongodb://file"}},"sink":{"type":"kafka"}}`
+	if err := os.WriteFile(path, []byte(fileConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	v, err := NewViper(path)
+	if err != nil {
+		t.Fatalf("NewViper failed: %v", err)
+	}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("source.settings.uri", "", "")
+	if err := flags.Set("source.settings.uri", "mongodb://flag"); err != nil {
+		t.Fatalf("failed to set flag: %v", err)
+	}
+	if err := v.BindPFlags(flags); err != nil {
+		t.Fatalf("BindPFlags failed: %v", err)
+	}
+
+	cfg, err := FromViper(v)
+	if err != nil {
+		t.Fatalf("FromViper failed: %v", err)
+	}
+
+	if got := cfg.Source.GetString("uri"); got != "mongodb://flag" {
+		t.Errorf("Source.GetString(uri) = %q, want %q (flag should win over config file)", got, "mongodb://flag")
+	}
+}
+
+func TestNestedSettingsEnvVarOverridesConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	fileConfig := `{"pipeline":{"name":"p"},"source":{"type":"mongodb","settings":{"uri":"mongodb://file"}},"sink":{"type":"kafka"}}`
+	if err := os.WriteFile(path, []byte(fileConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	t.Setenv("DATAPIPE_SOURCE_SETTINGS_URI", "mongodb://env")
+
+	v, err := NewViper(path)
+	if err != nil {
+		t.Fatalf("NewViper failed: %v", err)
+	}
+
+	cfg, err := FromViper(v)
+	if err != nil {
+		t.Fatalf("FromViper failed: %v", err)
+	}
+
+	if got := cfg.Source.GetString("uri"); got != "mongodb://env" {
+		t.Errorf("Source.GetString(uri) = %q, want %q (env var should win over config file)", got, "mongodb://env")
+	}
+}
+
+func TestLoggingFlagOverridesConfigFileAndDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	fileConfig := `{"pipeline":{"name":"p"},"source":{"type":"mongodb"},"sink":{"type":"kafka"},"logging":{"format":"text","level":"warn"}}`
+	if err := os.WriteFile(path, []byte(fileConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	v, err := NewViper(path)
+	if err != nil {
+		t.Fatalf("NewViper failed: %v", err)
+	}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("logging.format", "text", "")
+	flags.String("logging.level", "info", "")
+	if err := flags.Set("logging.format", "json"); err != nil {
+		t.Fatalf("failed to set flag: %v", err)
+	}
+	if err := v.BindPFlags(flags); err != nil {
+		t.Fatalf("BindPFlags failed: %v", err)
+	}
+
+	cfg, err := FromViper(v)
+	if err != nil {
+		t.Fatalf("FromViper failed: %v", err)
+	}
+
+	if cfg.Logging.Format != "json" {
+		t.Errorf("Logging.Format = %q, want %q (flag should win over config file)", cfg.Logging.Format, "json")
+	}
+	if cfg.Logging.Level != "warn" {
+		t.Errorf("Logging.Level = %q, want %q (config file should win over the flag's default)", cfg.Logging.Level, "warn")
+	}
+}
+
+// Sanity-check that SourceConfig really does unmarshal settings from the
+// nested "settings" key and nowhere else, since that's the mismatch the
+// flag/env wiring above has to target.
+func TestSourceConfigUnmarshalsNestedSettings(t *testing.T) {
+	var cfg Config
+	data := []byte(`{"source":{"type":"mongodb","settings":{"uri":"mongodb://x"},"uri":"mongodb://flat"}}`)
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got := cfg.Source.GetString("uri"); got != "mongodb://x" {
+		t.Errorf("Source.GetString(uri) = %q, want %q (flat \"uri\" key has no struct field to land on)", got, "mongodb://x")
+	}
+}
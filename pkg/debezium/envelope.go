@@ -0,0 +1,37 @@
+// Package debezium defines the Debezium-compatible CDC envelope shape
+// shared by the Kafka source and sink, so the two can't drift apart.
+package debezium
+
+// Envelope mirrors the shape of a Debezium change-event payload.
+type Envelope struct {
+	Before map[string]interface{} `json:"before"`
+	After  map[string]interface{} `json:"after"`
+	Op     string                 `json:"op"`
+	Source Source                 `json:"source"`
+	TsMs   int64                  `json:"ts_ms"`
+}
+
+// Source is the envelope's "source" block identifying where a change came
+// from.
+type Source struct {
+	Db    string `json:"db"`
+	Table string `json:"table"`
+	TsMs  int64  `json:"ts_ms"`
+}
+
+// OpToOperation maps Debezium's single-letter op codes to the operation
+// names used elsewhere in the pipeline.
+var OpToOperation = map[string]string{
+	"c": "insert",
+	"u": "update",
+	"d": "delete",
+	"r": "read",
+}
+
+// OperationToOp is the inverse of OpToOperation.
+var OperationToOp = map[string]string{
+	"insert": "c",
+	"update": "u",
+	"delete": "d",
+	"read":   "r",
+}
@@ -0,0 +1,11 @@
+package debezium
+
+import "testing"
+
+func TestOpToOperationIsInverseOfOperationToOp(t *testing.T) {
+	for op, operation := range OpToOperation {
+		if got := OperationToOp[operation]; got != op {
+			t.Errorf("OperationToOp[%q] = %q, want %q", operation, got, op)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Package logging provides slog helpers shared by the pipeline's adaptors.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// DedupeHandler wraps a slog.Handler and drops consecutive log records that
+// are identical to the previous one (same level, message, and attributes).
+// Change streams commonly flap and emit the same error repeatedly; this
+// keeps that from flooding the log.
+type DedupeHandler struct {
+	next slog.Handler
+
+	mu   sync.Mutex
+	last string
+}
+
+// NewDedupeHandler wraps next with duplicate suppression.
+func NewDedupeHandler(next slog.Handler) *DedupeHandler {
+	return &DedupeHandler{next: next}
+}
+
+// Enabled delegates to the wrapped handler.
+func (h *DedupeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle drops the record if it is identical to the immediately preceding
+// one, otherwise forwards it to the wrapped handler.
+func (h *DedupeHandler) Handle(ctx context.Context, record slog.Record) error {
+	key := recordKey(record)
+
+	h.mu.Lock()
+	duplicate := key == h.last
+	h.last = key
+	h.mu.Unlock()
+
+	if duplicate {
+		return nil
+	}
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs returns a new DedupeHandler wrapping the attributed handler.
+func (h *DedupeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewDedupeHandler(h.next.WithAttrs(attrs))
+}
+
+// WithGroup returns a new DedupeHandler wrapping the grouped handler.
+func (h *DedupeHandler) WithGroup(name string) slog.Handler {
+	return NewDedupeHandler(h.next.WithGroup(name))
+}
+
+// recordKey builds a string identifying a record's level, message, and
+// attributes, so two records can be compared for exact equality.
+func recordKey(record slog.Record) string {
+	key := record.Level.String() + "|" + record.Message
+	record.Attrs(func(attr slog.Attr) bool {
+		key += "|" + attr.String()
+		return true
+	})
+	return key
+}
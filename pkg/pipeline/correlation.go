@@ -0,0 +1,19 @@
+package pipeline
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// NewCorrelationID generates a random ID that a source stamps onto an Event
+// when it first reads it, so the event's journey through the transformer and
+// sink can be grepped out of the logs by a single value.
+func NewCorrelationID() string {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		// crypto/rand.Read only fails if the OS entropy source is broken,
+		// in which case the process has bigger problems than a collision.
+		panic(fmt.Sprintf("pipeline: failed to generate correlation ID: %v", err))
+	}
+	return fmt.Sprintf("%x", buf)
+}
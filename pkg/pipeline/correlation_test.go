@@ -0,0 +1,15 @@
+package pipeline
+
+import "testing"
+
+func TestNewCorrelationIDIsUnique(t *testing.T) {
+	a := NewCorrelationID()
+	b := NewCorrelationID()
+
+	if a == "" || b == "" {
+		t.Fatal("expected non-empty correlation IDs")
+	}
+	if a == b {
+		t.Errorf("expected distinct correlation IDs, got %q twice", a)
+	}
+}
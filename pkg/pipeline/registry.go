@@ -0,0 +1,129 @@
+package pipeline
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/config"
+)
+
+// SourceFactory constructs a Source from its configuration. Adaptor
+// packages register one under a name in their init() function.
+type SourceFactory func(cfg config.SourceConfig, logger *slog.Logger) (Source, error)
+
+// SinkFactory constructs a Sink from its configuration.
+type SinkFactory func(cfg config.SinkConfig, logger *slog.Logger) (Sink, error)
+
+// TransformerFactory constructs a Transformer from its configuration.
+type TransformerFactory func(cfg config.TransformerConfig) (Transformer, error)
+
+var (
+	registryMu           sync.RWMutex
+	sourceFactories      = map[string]SourceFactory{}
+	sinkFactories        = map[string]SinkFactory{}
+	transformerFactories = map[string]TransformerFactory{}
+)
+
+// RegisterSource makes a source adaptor available under name. It is meant to
+// be called from an adaptor package's init(), mirroring how database/sql
+// drivers register themselves. It panics on a duplicate name, since that
+// indicates two adaptor packages were imported for the same name.
+func RegisterSource(name string, factory SourceFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := sourceFactories[name]; exists {
+		panic(fmt.Sprintf("pipeline: source adaptor %q already registered", name))
+	}
+	sourceFactories[name] = factory
+}
+
+// RegisterSink makes a sink adaptor available under name.
+func RegisterSink(name string, factory SinkFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := sinkFactories[name]; exists {
+		panic(fmt.Sprintf("pipeline: sink adaptor %q already registered", name))
+	}
+	sinkFactories[name] = factory
+}
+
+// RegisterTransformer makes a transformer adaptor available under name.
+func RegisterTransformer(name string, factory TransformerFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := transformerFactories[name]; exists {
+		panic(fmt.Sprintf("pipeline: transformer adaptor %q already registered", name))
+	}
+	transformerFactories[name] = factory
+}
+
+// NewSource looks up the source adaptor registered under name and builds it
+// from cfg.
+func NewSource(name string, cfg config.SourceConfig, logger *slog.Logger) (Source, error) {
+	registryMu.RLock()
+	factory, ok := sourceFactories[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("pipeline: no source adaptor registered for %q", name)
+	}
+	return factory(cfg, logger)
+}
+
+// NewSink looks up the sink adaptor registered under name and builds it from
+// cfg.
+func NewSink(name string, cfg config.SinkConfig, logger *slog.Logger) (Sink, error) {
+	registryMu.RLock()
+	factory, ok := sinkFactories[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("pipeline: no sink adaptor registered for %q", name)
+	}
+	return factory(cfg, logger)
+}
+
+// NewTransformer looks up the transformer adaptor registered under name and
+// builds it from cfg.
+func NewTransformer(name string, cfg config.TransformerConfig) (Transformer, error) {
+	registryMu.RLock()
+	factory, ok := transformerFactories[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("pipeline: no transformer adaptor registered for %q", name)
+	}
+	return factory(cfg)
+}
+
+// AdaptorDescription describes a single registered adaptor for display, e.g.
+// by the list-adaptors CLI command.
+type AdaptorDescription struct {
+	Kind string // "source", "sink", or "transformer"
+	Name string
+}
+
+// DescribeAdaptors returns every registered adaptor, sorted by kind and then
+// name.
+func DescribeAdaptors() []AdaptorDescription {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	descs := make([]AdaptorDescription, 0, len(sourceFactories)+len(sinkFactories)+len(transformerFactories))
+	for name := range sourceFactories {
+		descs = append(descs, AdaptorDescription{Kind: "source", Name: name})
+	}
+	for name := range sinkFactories {
+		descs = append(descs, AdaptorDescription{Kind: "sink", Name: name})
+	}
+	for name := range transformerFactories {
+		descs = append(descs, AdaptorDescription{Kind: "transformer", Name: name})
+	}
+
+	sort.Slice(descs, func(i, j int) bool {
+		if descs[i].Kind != descs[j].Kind {
+			return descs[i].Kind < descs[j].Kind
+		}
+		return descs[i].Name < descs[j].Name
+	})
+	return descs
+}
@@ -0,0 +1,115 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/debezium"
+	"github.com/IEatCodeDaily/data-pipe/pkg/pipeline"
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaSink implements the Sink interface for Kafka, re-serializing
+// pipeline.Event values into Debezium-style CDC envelopes keyed by event ID.
+type KafkaSink struct {
+	brokers []string
+	topic   string
+	writer  *kafka.Writer
+	logger  *slog.Logger
+}
+
+// NewKafkaSink creates a new Kafka sink that publishes to topic.
+func NewKafkaSink(brokers []string, topic string, logger *slog.Logger) *KafkaSink {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &KafkaSink{
+		brokers: brokers,
+		topic:   topic,
+		logger:  logger.With("sink_type", "kafka", "topic", topic),
+	}
+}
+
+// Connect establishes the Kafka producer.
+func (k *KafkaSink) Connect(ctx context.Context) error {
+	k.logger.Info("connecting to Kafka", "brokers", k.brokers)
+
+	k.writer = &kafka.Writer{
+		Addr:         kafka.TCP(k.brokers...),
+		Topic:        k.topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}
+
+	k.logger.Info("successfully connected to Kafka")
+	return nil
+}
+
+// Write serializes the event into a Debezium-style envelope and publishes it
+// keyed by event.ID, so events that share an ID land on the same partition
+// and keep their order. Whether that groups changes by document depends on
+// the source: MongoDB change events carry the change stream's per-change _id.
+func (k *KafkaSink) Write(ctx context.Context, event pipeline.Event) error {
+	msg, err := ConvertEvent(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal debezium envelope: %w", err)
+	}
+
+	if err := k.writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("failed to write kafka message: %w", err)
+	}
+
+	k.logger.Debug("wrote kafka message", "event_id", event.ID, "correlation_id", event.CorrelationID, "operation", event.Operation, "collection", event.Collection)
+	return nil
+}
+
+// ConvertEvent encodes a pipeline.Event into a Debezium-style envelope
+// wrapped in a kafka.Message, mirroring the decode in
+// source.KafkaSource.ConvertMessage so the two stay in sync. It is exported
+// so tests (and any other adaptor needing the same wire format) don't have
+// to duplicate the encoding.
+func ConvertEvent(event pipeline.Event) (kafka.Message, error) {
+	op, ok := debezium.OperationToOp[event.Operation]
+	if !ok {
+		op = event.Operation
+	}
+
+	envelope := debezium.Envelope{
+		Op: op,
+		Source: debezium.Source{
+			Db:    event.Database,
+			Table: event.Collection,
+		},
+		TsMs: event.Timestamp.UnixMilli(),
+	}
+
+	if op == "d" {
+		envelope.Before = event.Data
+	} else {
+		envelope.After = event.Data
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+
+	return kafka.Message{
+		Key:   []byte(event.ID),
+		Value: payload,
+		Headers: []kafka.Header{
+			{Key: "correlation_id", Value: []byte(event.CorrelationID)},
+		},
+	}, nil
+}
+
+// Close closes the Kafka producer.
+func (k *KafkaSink) Close() error {
+	if k.writer != nil {
+		k.logger.Info("closing Kafka producer")
+		return k.writer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package sink
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/debezium"
+	"github.com/IEatCodeDaily/data-pipe/pkg/pipeline"
+	"github.com/IEatCodeDaily/data-pipe/pkg/source"
+)
+
+func TestConvertEventProducesDebeziumEnvelope(t *testing.T) {
+	event := pipeline.Event{
+		ID:            "abc123",
+		CorrelationID: "corr-1",
+		Database:      "db",
+		Collection:    "orders",
+		Operation:     "update",
+		Data:          map[string]interface{}{"status": "shipped"},
+		Timestamp:     time.UnixMilli(1700000000000),
+	}
+
+	msg, err := ConvertEvent(event)
+	if err != nil {
+		t.Fatalf("ConvertEvent failed: %v", err)
+	}
+
+	if string(msg.Key) != event.ID {
+		t.Errorf("msg.Key = %q, want %q", msg.Key, event.ID)
+	}
+
+	var envelope debezium.Envelope
+	if err := json.Unmarshal(msg.Value, &envelope); err != nil {
+		t.Fatalf("failed to unmarshal envelope: %v", err)
+	}
+	if envelope.Op != "u" {
+		t.Errorf("envelope.Op = %q, want %q", envelope.Op, "u")
+	}
+	if envelope.Source.Db != event.Database || envelope.Source.Table != event.Collection {
+		t.Errorf("envelope.Source = %+v, want db=%q table=%q", envelope.Source, event.Database, event.Collection)
+	}
+}
+
+// TestConvertEventRoundTripsWithConvertMessage locks in the contract
+// pkg/debezium was split out to share: a Kafka sink's ConvertEvent output
+// must decode back into an equivalent pipeline.Event via the Kafka source's
+// ConvertMessage.
+func TestConvertEventRoundTripsWithConvertMessage(t *testing.T) {
+	event := pipeline.Event{
+		ID:            "abc123",
+		CorrelationID: "corr-1",
+		Database:      "db",
+		Collection:    "orders",
+		Operation:     "delete",
+		Data:          map[string]interface{}{"status": "cancelled"},
+		Timestamp:     time.UnixMilli(1700000000000),
+	}
+
+	msg, err := ConvertEvent(event)
+	if err != nil {
+		t.Fatalf("ConvertEvent failed: %v", err)
+	}
+
+	src := source.NewKafkaSource(nil, nil, "", nil)
+	got, err := src.ConvertMessage(msg)
+	if err != nil {
+		t.Fatalf("ConvertMessage failed: %v", err)
+	}
+
+	if got.ID != event.ID {
+		t.Errorf("got.ID = %q, want %q", got.ID, event.ID)
+	}
+	if got.Source != "kafka" {
+		t.Errorf("got.Source = %q, want %q", got.Source, "kafka")
+	}
+	if got.Database != event.Database {
+		t.Errorf("got.Database = %q, want %q", got.Database, event.Database)
+	}
+	if got.Collection != event.Collection {
+		t.Errorf("got.Collection = %q, want %q", got.Collection, event.Collection)
+	}
+	if got.Operation != event.Operation {
+		t.Errorf("got.Operation = %q, want %q", got.Operation, event.Operation)
+	}
+	if got.Timestamp.UnixMilli() != event.Timestamp.UnixMilli() {
+		t.Errorf("got.Timestamp = %v, want %v", got.Timestamp, event.Timestamp)
+	}
+}
@@ -0,0 +1,109 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/pipeline"
+	_ "github.com/lib/pq"
+)
+
+// validTableIdentifier matches the table names PostgreSQLSink allows: a
+// bare, unquoted SQL identifier. Table names are operator config resolved
+// via tableForCollection, but they are spliced directly into query text
+// below, so they are checked against this allowlist rather than quoted.
+var validTableIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// PostgreSQLSink implements the Sink interface for PostgreSQL, upserting
+// each event's document as a JSONB row keyed by event ID. The destination
+// table is resolved per event via tableForCollection, so a single sink can
+// fan out writes from a multi-collection source (e.g. MongoDBSource with a
+// regex collection pattern) to different tables. The table(s) are expected
+// to already exist; callers are responsible for running the corresponding
+// migration, matching how pkg/source/resume treats its backing table as
+// externally managed.
+//
+//	CREATE TABLE <table> (
+//	    id          TEXT PRIMARY KEY,
+//	    operation   TEXT NOT NULL,
+//	    data        JSONB NOT NULL,
+//	    occurred_at TIMESTAMPTZ NOT NULL
+//	);
+type PostgreSQLSink struct {
+	connStr            string
+	tableForCollection func(collection string) string
+	db                 *sql.DB
+	logger             *slog.Logger
+}
+
+// NewPostgreSQLSink creates a new PostgreSQL sink that connects to connStr.
+// tableForCollection resolves the destination table for an event's source
+// collection; a sink configured with a single flat table setting can pass a
+// function that always returns that table, e.g. config.SinkConfig's own
+// TableForCollection method.
+func NewPostgreSQLSink(connStr string, tableForCollection func(collection string) string, logger *slog.Logger) *PostgreSQLSink {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &PostgreSQLSink{
+		connStr:            connStr,
+		tableForCollection: tableForCollection,
+		logger:             logger.With("sink_type", "postgresql"),
+	}
+}
+
+// Connect opens the PostgreSQL connection pool.
+func (p *PostgreSQLSink) Connect(ctx context.Context) error {
+	p.logger.Info("connecting to PostgreSQL")
+
+	db, err := sql.Open("postgres", p.connStr)
+	if err != nil {
+		return fmt.Errorf("failed to open PostgreSQL connection: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	}
+
+	p.db = db
+	p.logger.Info("successfully connected to PostgreSQL")
+	return nil
+}
+
+// Write upserts event into the table resolved for its source collection.
+func (p *PostgreSQLSink) Write(ctx context.Context, event pipeline.Event) error {
+	table := p.tableForCollection(event.Collection)
+	if !validTableIdentifier.MatchString(table) {
+		return fmt.Errorf("resolved table %q is not a valid identifier", table)
+	}
+
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, operation, data, occurred_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id)
+		DO UPDATE SET operation = EXCLUDED.operation, data = EXCLUDED.data, occurred_at = EXCLUDED.occurred_at`, table)
+
+	if _, err := p.db.ExecContext(ctx, query, event.ID, event.Operation, data, event.Timestamp); err != nil {
+		return fmt.Errorf("failed to write event to PostgreSQL: %w", err)
+	}
+
+	p.logger.Debug("wrote event to PostgreSQL", "event_id", event.ID, "correlation_id", event.CorrelationID, "table", table, "operation", event.Operation)
+	return nil
+}
+
+// Close closes the PostgreSQL connection pool.
+func (p *PostgreSQLSink) Close() error {
+	if p.db != nil {
+		p.logger.Info("closing PostgreSQL connection")
+		return p.db.Close()
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package sink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/pipeline"
+)
+
+func TestPostgreSQLSinkWriteRejectsInvalidResolvedTable(t *testing.T) {
+	sink := NewPostgreSQLSink("", func(collection string) string {
+		return "orders; DROP TABLE users;--"
+	}, nil)
+
+	err := sink.Write(context.Background(), pipeline.Event{Collection: "orders"})
+	if err == nil {
+		t.Fatal("Write with an invalid resolved table = nil error, want error")
+	}
+}
@@ -0,0 +1,24 @@
+package sink
+
+import (
+	"log/slog"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/config"
+	"github.com/IEatCodeDaily/data-pipe/pkg/pipeline"
+)
+
+func init() {
+	pipeline.RegisterSink("postgresql", newPostgreSQLSinkFromConfig)
+	pipeline.RegisterSink("kafka", newKafkaSinkFromConfig)
+}
+
+func newPostgreSQLSinkFromConfig(cfg config.SinkConfig, logger *slog.Logger) (pipeline.Sink, error) {
+	connStr := cfg.GetString("connection_string")
+	return NewPostgreSQLSink(connStr, cfg.TableForCollection, logger), nil
+}
+
+func newKafkaSinkFromConfig(cfg config.SinkConfig, logger *slog.Logger) (pipeline.Sink, error) {
+	brokers := cfg.GetStringSlice("brokers")
+	topic := cfg.GetString("topic")
+	return NewKafkaSink(brokers, topic, logger), nil
+}
@@ -2,41 +2,128 @@ package source
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/IEatCodeDaily/data-pipe/pkg/pipeline"
+	"github.com/IEatCodeDaily/data-pipe/pkg/source/resume"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// changeStreamHistoryLostCode is the MongoDB server error code returned when
+// a change stream's resume point has fallen off the oplog.
+const changeStreamHistoryLostCode = 286
+
+// Snapshot/streaming modes for MongoDBSource.
+const (
+	ModeStream         = "stream"          // tail the change stream only (default)
+	ModeSnapshot       = "snapshot"        // one-time backfill, then exit
+	ModeSnapshotStream = "snapshot+stream" // backfill, then tail from the pre-snapshot point
+)
+
+// phaseSnapshotComplete is the marker value persisted once a snapshot has
+// finished, so a restart doesn't repeat it.
+const phaseSnapshotComplete = "complete"
+
+// regexMetaChars are the characters that, if present in a configured
+// collection name, mark it as a regex pattern rather than a literal name.
+const regexMetaChars = `^$*+?()[]{}|\`
+
 // MongoDBSource implements the Source interface for MongoDB
 type MongoDBSource struct {
 	uri        string
 	database   string
 	collection string
+	collRegexp *regexp.Regexp
 	client     *mongo.Client
-	logger     *log.Logger
+	logger     *slog.Logger
+
+	pipelineName string
+	resumeStore  resume.Store
+	pending      sync.Map // event ID -> raw resume token, awaiting Ack
+
+	mode              string
+	snapshotBatchSize int32
+
+	historyLostFallback bool
+}
+
+// WithResumeStore configures m to persist its change stream resume token to
+// store after each acknowledged event, and to resume from it on the next
+// Read. pipelineName keys the token within store, so multiple pipelines can
+// share a single store. It returns m for chaining off NewMongoDBSource.
+func (m *MongoDBSource) WithResumeStore(store resume.Store, pipelineName string) *MongoDBSource {
+	m.resumeStore = store
+	m.pipelineName = pipelineName
+	return m
+}
+
+// WithMode configures whether Read tails the change stream (ModeStream, the
+// default), performs a one-time backfill (ModeSnapshot), or backfills before
+// tailing (ModeSnapshotStream). snapshotBatchSize controls the cursor batch
+// size used during a backfill; 0 leaves the driver default.
+func (m *MongoDBSource) WithMode(mode string, snapshotBatchSize int32) *MongoDBSource {
+	m.mode = mode
+	m.snapshotBatchSize = snapshotBatchSize
+	return m
+}
+
+// WithHistoryLostFallback configures whether Read recovers from a
+// ChangeStreamHistoryLost error by re-running a snapshot and resuming the
+// change stream from scratch, instead of treating it as fatal. It defaults
+// to off, since a resync can be an expensive, disruptive operation that
+// operators may want to opt into explicitly.
+func (m *MongoDBSource) WithHistoryLostFallback(enabled bool) *MongoDBSource {
+	m.historyLostFallback = enabled
+	return m
 }
 
-// NewMongoDBSource creates a new MongoDB source
-func NewMongoDBSource(uri, database, collection string, logger *log.Logger) *MongoDBSource {
+// NewMongoDBSource creates a new MongoDB source. If collection looks like a
+// regex pattern (e.g. "^orders_.*") rather than a literal name, the source
+// watches the whole database and filters emitted events by matching each
+// change's namespace collection against the compiled pattern.
+func NewMongoDBSource(uri, database, collection string, logger *slog.Logger) *MongoDBSource {
 	if logger == nil {
-		logger = log.Default()
+		logger = slog.Default()
 	}
-	return &MongoDBSource{
+	logger = logger.With("source_type", "mongodb", "database", database)
+
+	source := &MongoDBSource{
 		uri:        uri,
 		database:   database,
 		collection: collection,
 		logger:     logger,
 	}
+
+	if looksLikeRegex(collection) {
+		if compiled, err := regexp.Compile(collection); err == nil {
+			source.collRegexp = compiled
+		} else {
+			logger.Warn("collection pattern failed to compile, treating as a literal name", "collection", collection, "error", err)
+		}
+	}
+
+	return source
+}
+
+// looksLikeRegex reports whether a configured collection name contains any
+// of regexMetaChars, in which case it is treated as a pattern rather than a
+// literal collection name. '.' is not among them, so a dotted name such as
+// "orders.v2" stays literal.
+func looksLikeRegex(collection string) bool {
+	return strings.ContainsAny(collection, regexMetaChars)
 }
 
 // Connect establishes connection to MongoDB
 func (m *MongoDBSource) Connect(ctx context.Context) error {
-	m.logger.Printf("Connecting to MongoDB: %s", m.uri)
+	m.logger.Info("connecting to MongoDB", "uri", m.uri)
 
 	clientOptions := options.Client().ApplyURI(m.uri)
 	client, err := mongo.Connect(ctx, clientOptions)
@@ -50,11 +137,22 @@ func (m *MongoDBSource) Connect(ctx context.Context) error {
 	}
 
 	m.client = client
-	m.logger.Println("Successfully connected to MongoDB")
+	m.logger.Info("successfully connected to MongoDB")
 	return nil
 }
 
-// Read reads change events from MongoDB using change streams
+// Read reads events from MongoDB according to the configured mode. In
+// ModeStream (the default) it tails the change stream, resuming from the
+// last acknowledged token when a resume store is configured. In
+// ModeSnapshot it performs a one-time Find-based backfill and exits. In
+// ModeSnapshotStream it backfills first, having captured the change
+// stream's resume token before the backfill began, then tails from that
+// point so no events are lost across the transition. The pre-backfill token
+// is captured only when a resume store is configured; without one the
+// stream starts from the moment the backfill finishes. If the stream's resume
+// point falls off the oplog (ChangeStreamHistoryLost) and
+// WithHistoryLostFallback was enabled, Read recovers by re-running a
+// snapshot and resuming the stream from scratch instead of failing.
 func (m *MongoDBSource) Read(ctx context.Context) (<-chan pipeline.Event, <-chan error) {
 	events := make(chan pipeline.Event)
 	errors := make(chan error)
@@ -63,46 +161,299 @@ func (m *MongoDBSource) Read(ctx context.Context) (<-chan pipeline.Event, <-chan
 		defer close(events)
 		defer close(errors)
 
-		collection := m.client.Database(m.database).Collection(m.collection)
+		if m.mode == ModeSnapshot || m.mode == ModeSnapshotStream {
+			if m.mode == ModeSnapshotStream {
+				if err := m.captureSnapshotStartToken(ctx); err != nil {
+					errors <- err
+					return
+				}
+			}
 
-		// Create a change stream
-		pipeline := mongo.Pipeline{}
-		opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+			done, err := m.snapshotComplete(ctx)
+			if err != nil {
+				errors <- err
+				return
+			}
 
-		m.logger.Printf("Starting change stream for %s.%s", m.database, m.collection)
-		stream, err := collection.Watch(ctx, pipeline, opts)
-		if err != nil {
-			errors <- fmt.Errorf("failed to create change stream: %w", err)
-			return
+			if done {
+				m.logger.Info("snapshot already completed, skipping")
+			} else {
+				if err := m.runSnapshot(ctx, events); err != nil {
+					errors <- fmt.Errorf("snapshot failed: %w", err)
+					return
+				}
+				if err := m.markSnapshotComplete(ctx); err != nil {
+					errors <- err
+					return
+				}
+			}
+
+			if m.mode == ModeSnapshot {
+				return
+			}
 		}
-		defer stream.Close(ctx)
 
-		for stream.Next(ctx) {
-			var changeDoc bson.M
-			if err := stream.Decode(&changeDoc); err != nil {
-				errors <- fmt.Errorf("failed to decode change event: %w", err)
-				continue
+		useResumeToken := true
+		for {
+			historyLost, err := m.streamChanges(ctx, events, errors, useResumeToken)
+			if err != nil {
+				errors <- err
+				return
+			}
+			if !historyLost {
+				return
 			}
 
-			event := m.convertChangeEvent(changeDoc)
-			events <- event
-		}
+			if !m.historyLostFallback {
+				errors <- fmt.Errorf("change stream history lost, resume token is stale")
+				return
+			}
 
-		if err := stream.Err(); err != nil {
-			errors <- fmt.Errorf("change stream error: %w", err)
+			m.logger.Warn("change stream history lost, falling back to a fresh snapshot", "collection", m.collection)
+			if err := m.runSnapshot(ctx, events); err != nil {
+				errors <- fmt.Errorf("snapshot failed after history lost: %w", err)
+				return
+			}
+			useResumeToken = false
 		}
 	}()
 
 	return events, errors
 }
 
+// streamChanges opens a change stream and forwards events to the events
+// channel until it is exhausted or fails. It reports historyLost=true when
+// the stream stopped because the resume point fell off the oplog, leaving
+// the caller to decide whether to fall back to a snapshot.
+func (m *MongoDBSource) streamChanges(ctx context.Context, events chan<- pipeline.Event, errors chan<- error, useResumeToken bool) (historyLost bool, err error) {
+	stream, err := m.openChangeStream(ctx, useResumeToken)
+	if err != nil {
+		return false, err
+	}
+	defer stream.Close(ctx)
+
+	for stream.Next(ctx) {
+		var changeDoc bson.M
+		if err := stream.Decode(&changeDoc); err != nil {
+			errors <- fmt.Errorf("failed to decode change event: %w", err)
+			continue
+		}
+
+		matchedCollection := m.matchedCollection(changeDoc)
+		if matchedCollection == "" {
+			// Database-level watch saw a namespace that doesn't match our
+			// pattern; skip it.
+			continue
+		}
+
+		event := m.convertChangeEvent(changeDoc, matchedCollection)
+		if resumeToken, ok := changeDoc["_id"]; ok && m.resumeStore != nil {
+			if raw, err := bson.Marshal(resumeToken); err == nil {
+				m.pending.Store(event.ID, raw)
+			}
+		}
+		m.logger.Debug("read change event", "event_id", event.ID, "correlation_id", event.CorrelationID, "operation", event.Operation, "collection", event.Collection)
+		events <- event
+	}
+
+	if err := stream.Err(); err != nil {
+		if isChangeStreamHistoryLost(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("change stream error: %w", err)
+	}
+	return false, nil
+}
+
+// openChangeStream opens a change stream, resuming from the last persisted
+// token when useResumeToken is true and a token has been saved.
+func (m *MongoDBSource) openChangeStream(ctx context.Context, useResumeToken bool) (*mongo.ChangeStream, error) {
+	changeStreamPipeline := mongo.Pipeline{}
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+
+	if useResumeToken && m.resumeStore != nil {
+		token, err := m.resumeStore.Load(ctx, m.pipelineName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load resume token: %w", err)
+		}
+		if token != nil {
+			opts.SetResumeAfter(bson.Raw(token))
+		}
+	}
+
+	var stream *mongo.ChangeStream
+	var err error
+	if m.collRegexp != nil {
+		m.logger.Info("starting database-level change stream", "collection_pattern", m.collection)
+		stream, err = m.client.Database(m.database).Watch(ctx, changeStreamPipeline, opts)
+	} else {
+		m.logger.Info("starting change stream", "collection", m.collection)
+		stream, err = m.client.Database(m.database).Collection(m.collection).Watch(ctx, changeStreamPipeline, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create change stream: %w", err)
+	}
+	return stream, nil
+}
+
+// runSnapshot performs a single Find pass over the collection, emitting a
+// synthetic read event for each document so downstream sinks can treat a
+// backfill the same way as a live read.
+func (m *MongoDBSource) runSnapshot(ctx context.Context, events chan<- pipeline.Event) error {
+	m.logger.Info("starting snapshot", "collection", m.collection, "batch_size", m.snapshotBatchSize)
+
+	findOpts := options.Find()
+	if m.snapshotBatchSize > 0 {
+		findOpts.SetBatchSize(m.snapshotBatchSize)
+	}
+
+	cursor, err := m.client.Database(m.database).Collection(m.collection).Find(ctx, bson.D{}, findOpts)
+	if err != nil {
+		return fmt.Errorf("failed to start snapshot cursor: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var count int
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("failed to decode snapshot document: %w", err)
+		}
+
+		events <- pipeline.Event{
+			ID:            fmt.Sprintf("%v", doc["_id"]),
+			CorrelationID: pipeline.NewCorrelationID(),
+			Source:        "mongodb",
+			Database:      m.database,
+			Collection:    m.collection,
+			Operation:     "read",
+			Data:          convertBSONToMap(doc),
+			Timestamp:     time.Now(),
+		}
+		count++
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("snapshot cursor error: %w", err)
+	}
+
+	m.logger.Info("snapshot complete", "documents", count)
+	return nil
+}
+
+// captureSnapshotStartToken records the change stream's current resume token
+// before a ModeSnapshotStream backfill begins, so the stream can later
+// resume from the instant the snapshot started rather than the instant it
+// finished. It is a no-op if no resume store is configured, or if a resume
+// token has already been saved, e.g. on a restart after the snapshot
+// completed.
+func (m *MongoDBSource) captureSnapshotStartToken(ctx context.Context) error {
+	if m.resumeStore == nil {
+		return nil
+	}
+
+	existing, err := m.resumeStore.Load(ctx, m.pipelineName)
+	if err != nil {
+		return fmt.Errorf("failed to load resume token: %w", err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	stream, err := m.openChangeStream(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to open change stream to capture snapshot start token: %w", err)
+	}
+	defer stream.Close(ctx)
+
+	token := stream.ResumeToken()
+	if token == nil {
+		return fmt.Errorf("failed to capture a resume token before snapshot")
+	}
+	return m.resumeStore.Save(ctx, m.pipelineName, []byte(token))
+}
+
+// phaseKey namespaces the snapshot-complete marker from the resume token
+// itself, since both are stored in the same resume.Store under pipelineName.
+func (m *MongoDBSource) phaseKey() string {
+	return m.pipelineName + ":phase"
+}
+
+// snapshotComplete reports whether a prior run already finished the
+// backfill for this pipeline.
+func (m *MongoDBSource) snapshotComplete(ctx context.Context) (bool, error) {
+	if m.resumeStore == nil {
+		return false, nil
+	}
+	marker, err := m.resumeStore.Load(ctx, m.phaseKey())
+	if err != nil {
+		return false, fmt.Errorf("failed to load snapshot phase marker: %w", err)
+	}
+	return string(marker) == phaseSnapshotComplete, nil
+}
+
+// markSnapshotComplete persists the snapshot-complete marker so a restart
+// skips straight to streaming.
+func (m *MongoDBSource) markSnapshotComplete(ctx context.Context) error {
+	if m.resumeStore == nil {
+		return nil
+	}
+	return m.resumeStore.Save(ctx, m.phaseKey(), []byte(phaseSnapshotComplete))
+}
+
+// isChangeStreamHistoryLost reports whether err is the server telling us the
+// stream's resume point has fallen off the oplog.
+func isChangeStreamHistoryLost(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == changeStreamHistoryLostCode
+	}
+	return false
+}
+
+// Ack persists the resume token for a previously read event. It should be
+// called only after the event has been successfully written by the sink, so
+// a restart resumes from the last durably sunk event rather than skipping
+// ahead.
+func (m *MongoDBSource) Ack(ctx context.Context, event pipeline.Event) error {
+	if m.resumeStore == nil {
+		return nil
+	}
+
+	raw, ok := m.pending.LoadAndDelete(event.ID)
+	if !ok {
+		return fmt.Errorf("no pending resume token for event %s", event.ID)
+	}
+	return m.resumeStore.Save(ctx, m.pipelineName, raw.([]byte))
+}
+
+// matchedCollection returns the collection name a change event belongs to
+// if it should be emitted, or "" if it should be filtered out. When no regex
+// pattern is configured, every event from the watched collection matches.
+func (m *MongoDBSource) matchedCollection(changeDoc bson.M) string {
+	ns, ok := changeDoc["ns"].(bson.M)
+	if !ok {
+		return m.collection
+	}
+
+	coll, _ := ns["coll"].(string)
+	if m.collRegexp == nil {
+		return coll
+	}
+
+	if m.collRegexp.MatchString(coll) {
+		return coll
+	}
+	return ""
+}
+
 // convertChangeEvent converts MongoDB change stream event to pipeline event
-func (m *MongoDBSource) convertChangeEvent(changeDoc bson.M) pipeline.Event {
+func (m *MongoDBSource) convertChangeEvent(changeDoc bson.M, matchedCollection string) pipeline.Event {
 	event := pipeline.Event{
-		Source:     "mongodb",
-		Database:   m.database,
-		Collection: m.collection,
-		Timestamp:  time.Now(),
+		CorrelationID: pipeline.NewCorrelationID(),
+		Source:        "mongodb",
+		Database:      m.database,
+		Collection:    matchedCollection,
+		Timestamp:     time.Now(),
 	}
 
 	if id, ok := changeDoc["_id"]; ok {
@@ -143,7 +494,7 @@ func convertBSONToMap(doc bson.M) map[string]interface{} {
 // Close closes the MongoDB connection
 func (m *MongoDBSource) Close() error {
 	if m.client != nil {
-		m.logger.Println("Closing MongoDB connection")
+		m.logger.Info("closing MongoDB connection")
 		return m.client.Disconnect(context.Background())
 	}
 	return nil
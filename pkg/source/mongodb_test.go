@@ -0,0 +1,152 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/source/resume"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestLooksLikeRegex(t *testing.T) {
+	cases := []struct {
+		collection string
+		want       bool
+	}{
+		{"orders", false},
+		{"orders_2024", false},
+		{"^orders_.*", true},
+		{"orders|invoices", true},
+		{"orders.v2", false},
+	}
+	for _, tc := range cases {
+		if got := looksLikeRegex(tc.collection); got != tc.want {
+			t.Errorf("looksLikeRegex(%q) = %v, want %v", tc.collection, got, tc.want)
+		}
+	}
+}
+
+func TestMatchedCollectionLiteral(t *testing.T) {
+	m := NewMongoDBSource("mongodb://x", "db", "orders", nil)
+
+	changeDoc := bson.M{"ns": bson.M{"db": "db", "coll": "orders"}}
+	if got := m.matchedCollection(changeDoc); got != "orders" {
+		t.Errorf("matchedCollection = %q, want %q", got, "orders")
+	}
+}
+
+func TestMatchedCollectionRegexFiltersNonMatching(t *testing.T) {
+	m := NewMongoDBSource("mongodb://x", "db", "^orders_.*", nil)
+	if m.collRegexp == nil {
+		t.Fatal("expected collection pattern to compile to a regexp")
+	}
+
+	matching := bson.M{"ns": bson.M{"db": "db", "coll": "orders_2024"}}
+	if got := m.matchedCollection(matching); got != "orders_2024" {
+		t.Errorf("matchedCollection(matching) = %q, want %q", got, "orders_2024")
+	}
+
+	nonMatching := bson.M{"ns": bson.M{"db": "db", "coll": "invoices"}}
+	if got := m.matchedCollection(nonMatching); got != "" {
+		t.Errorf("matchedCollection(non-matching) = %q, want empty string", got)
+	}
+}
+
+func TestMatchedCollectionMissingNamespaceFallsBackToConfiguredName(t *testing.T) {
+	m := NewMongoDBSource("mongodb://x", "db", "orders", nil)
+
+	if got := m.matchedCollection(bson.M{}); got != "orders" {
+		t.Errorf("matchedCollection(no ns) = %q, want %q", got, "orders")
+	}
+}
+
+func TestSnapshotPhaseMarkerRoundTrip(t *testing.T) {
+	store := resume.NewFileStore(filepath.Join(t.TempDir(), "resume.json"))
+	m := NewMongoDBSource("mongodb://x", "db", "orders", nil).WithResumeStore(store, "my-pipeline")
+	ctx := context.Background()
+
+	done, err := m.snapshotComplete(ctx)
+	if err != nil {
+		t.Fatalf("snapshotComplete failed: %v", err)
+	}
+	if done {
+		t.Fatal("snapshotComplete = true before any snapshot ran")
+	}
+
+	if err := m.markSnapshotComplete(ctx); err != nil {
+		t.Fatalf("markSnapshotComplete failed: %v", err)
+	}
+
+	done, err = m.snapshotComplete(ctx)
+	if err != nil {
+		t.Fatalf("snapshotComplete failed: %v", err)
+	}
+	if !done {
+		t.Error("snapshotComplete = false after markSnapshotComplete, want true")
+	}
+}
+
+// TestSnapshotPhaseMarkerDoesNotClobberResumeToken guards against the
+// regression where the phase marker and the resume token shared a single
+// key in the backing store, so marking the snapshot complete overwrote a
+// resume token that had already been saved (e.g. by Ack, or by
+// captureSnapshotStartToken for ModeSnapshotStream).
+func TestSnapshotPhaseMarkerDoesNotClobberResumeToken(t *testing.T) {
+	store := resume.NewFileStore(filepath.Join(t.TempDir(), "resume.json"))
+	m := NewMongoDBSource("mongodb://x", "db", "orders", nil).WithResumeStore(store, "my-pipeline")
+	ctx := context.Background()
+
+	if err := store.Save(ctx, "my-pipeline", []byte("a-real-resume-token")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if err := m.markSnapshotComplete(ctx); err != nil {
+		t.Fatalf("markSnapshotComplete failed: %v", err)
+	}
+
+	token, err := store.Load(ctx, "my-pipeline")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if string(token) != "a-real-resume-token" {
+		t.Errorf("resume token = %q after markSnapshotComplete, want %q unchanged", token, "a-real-resume-token")
+	}
+}
+
+func TestIsChangeStreamHistoryLost(t *testing.T) {
+	historyLost := mongo.CommandError{Code: changeStreamHistoryLostCode, Name: "ChangeStreamHistoryLost", Message: "resume point not found"}
+	if !isChangeStreamHistoryLost(historyLost) {
+		t.Error("isChangeStreamHistoryLost(history lost) = false, want true")
+	}
+
+	// Still true wrapped, since streamChanges sees it via stream.Err(),
+	// and callers may wrap it further up the stack.
+	if !isChangeStreamHistoryLost(fmt.Errorf("change stream error: %w", historyLost)) {
+		t.Error("isChangeStreamHistoryLost(wrapped history lost) = false, want true")
+	}
+
+	other := mongo.CommandError{Code: 11600, Name: "InterruptedAtShutdown", Message: "interrupted"}
+	if isChangeStreamHistoryLost(other) {
+		t.Error("isChangeStreamHistoryLost(other command error) = true, want false")
+	}
+
+	if isChangeStreamHistoryLost(errors.New("boom")) {
+		t.Error("isChangeStreamHistoryLost(plain error) = true, want false")
+	}
+}
+
+func TestWithHistoryLostFallbackDefaultsToDisabled(t *testing.T) {
+	m := NewMongoDBSource("mongodb://x", "db", "orders", nil)
+	if m.historyLostFallback {
+		t.Error("historyLostFallback = true by default, want false")
+	}
+
+	m = m.WithHistoryLostFallback(true)
+	if !m.historyLostFallback {
+		t.Error("historyLostFallback = false after WithHistoryLostFallback(true), want true")
+	}
+}
@@ -0,0 +1,87 @@
+package source
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/config"
+	"github.com/IEatCodeDaily/data-pipe/pkg/pipeline"
+	"github.com/IEatCodeDaily/data-pipe/pkg/source/resume"
+	_ "github.com/lib/pq"
+)
+
+func init() {
+	pipeline.RegisterSource("mongodb", newMongoDBSourceFromConfig)
+	pipeline.RegisterSource("kafka", newKafkaSourceFromConfig)
+}
+
+func newMongoDBSourceFromConfig(cfg config.SourceConfig, logger *slog.Logger) (pipeline.Source, error) {
+	uri := cfg.GetString("uri")
+	database := cfg.GetString("database")
+	collection := cfg.GetString("collection")
+
+	src := NewMongoDBSource(uri, database, collection, logger)
+
+	resumeStore, err := buildResumeStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if resumeStore != nil {
+		src = src.WithResumeStore(resumeStore, cfg.GetString("pipeline_name"))
+	}
+
+	mode := cfg.GetString("mode")
+	if mode != "" {
+		src = src.WithMode(mode, int32(cfg.GetInt("snapshot_batch_size")))
+	}
+
+	src = src.WithHistoryLostFallback(cfg.GetBool("history_lost_fallback"))
+
+	return src, nil
+}
+
+func newKafkaSourceFromConfig(cfg config.SourceConfig, logger *slog.Logger) (pipeline.Source, error) {
+	brokers := cfg.GetStringSlice("brokers")
+	topics := cfg.GetStringSlice("topics")
+	groupID := cfg.GetString("group_id")
+
+	return NewKafkaSource(brokers, topics, groupID, logger), nil
+}
+
+// buildResumeStore configures a resume.Store from the source's
+// "resume_store" settings, or returns nil if none is configured.
+//
+//	"resume_store": {
+//	    "type": "file",
+//	    "path": "/var/lib/data-pipe/resume-token"
+//	}
+func buildResumeStore(cfg config.SourceConfig) (resume.Store, error) {
+	settings, ok := cfg.Settings["resume_store"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	storeType, _ := settings["type"].(string)
+	switch storeType {
+	case "file":
+		path, _ := settings["path"].(string)
+		if path == "" {
+			return nil, fmt.Errorf("resume_store.path is required for type \"file\"")
+		}
+		return resume.NewFileStore(path), nil
+	case "postgres":
+		connStr, _ := settings["connection_string"].(string)
+		if connStr == "" {
+			return nil, fmt.Errorf("resume_store.connection_string is required for type \"postgres\"")
+		}
+		db, err := sql.Open("postgres", connStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open resume store database: %w", err)
+		}
+		table, _ := settings["table"].(string)
+		return resume.NewPostgresStore(db, table)
+	default:
+		return nil, fmt.Errorf("unsupported resume_store type: %q", storeType)
+	}
+}
@@ -0,0 +1,79 @@
+package resume
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileStore persists resume tokens as a single JSON file on disk, keyed by
+// the key argument passed to Load/Save. This lets a single FileStore back
+// more than one logical key (e.g. a pipeline's resume token and a separate
+// phase marker) without one overwriting the other.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore creates a resume.Store backed by the file at path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load reads the resume token stored under key, returning a nil token if
+// none has been saved yet.
+func (f *FileStore) Load(ctx context.Context, key string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.readAll()
+	if err != nil {
+		return nil, err
+	}
+	return tokens[key], nil
+}
+
+// Save writes the resume token under key, leaving any other keys already
+// persisted in the file untouched.
+func (f *FileStore) Save(ctx context.Context, key string, token []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.readAll()
+	if err != nil {
+		return err
+	}
+	tokens[key] = token
+
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("failed to encode resume tokens for %s: %w", f.path, err)
+	}
+	if err := os.WriteFile(f.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write resume tokens to %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// readAll loads the full key->token map from disk, returning an empty map
+// if the file does not exist yet.
+func (f *FileStore) readAll() (map[string][]byte, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string][]byte{}, nil
+		}
+		return nil, fmt.Errorf("failed to read resume tokens from %s: %w", f.path, err)
+	}
+
+	tokens := map[string][]byte{}
+	if len(data) == 0 {
+		return tokens, nil
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to decode resume tokens from %s: %w", f.path, err)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,47 @@
+package resume
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStoreKeysDoNotCollide(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "resume.json"))
+	ctx := context.Background()
+
+	if err := store.Save(ctx, "orders", []byte("token-a")); err != nil {
+		t.Fatalf("Save(orders) failed: %v", err)
+	}
+	if err := store.Save(ctx, "orders:phase", []byte("complete")); err != nil {
+		t.Fatalf("Save(orders:phase) failed: %v", err)
+	}
+
+	token, err := store.Load(ctx, "orders")
+	if err != nil {
+		t.Fatalf("Load(orders) failed: %v", err)
+	}
+	if string(token) != "token-a" {
+		t.Errorf("Load(orders) = %q, want %q", token, "token-a")
+	}
+
+	phase, err := store.Load(ctx, "orders:phase")
+	if err != nil {
+		t.Fatalf("Load(orders:phase) failed: %v", err)
+	}
+	if string(phase) != "complete" {
+		t.Errorf("Load(orders:phase) = %q, want %q", phase, "complete")
+	}
+}
+
+func TestFileStoreLoadMissingKey(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "resume.json"))
+
+	token, err := store.Load(context.Background(), "unset")
+	if err != nil {
+		t.Fatalf("Load(unset) failed: %v", err)
+	}
+	if token != nil {
+		t.Errorf("Load(unset) = %q, want nil", token)
+	}
+}
@@ -0,0 +1,75 @@
+package resume
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// validIdentifier matches the identifiers PostgresStore allows for its
+// table name: a bare, unquoted SQL identifier. The table name is operator
+// config, not user input, but it is spliced directly into query text below,
+// so it is validated against this allowlist rather than quoted, since
+// quoting a hostile value doesn't stop it from closing the identifier.
+var validIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// PostgresStore persists resume tokens in a Postgres table, keyed by
+// pipeline name. The table is expected to already exist; callers are
+// responsible for running the corresponding migration, matching how the
+// rest of the pipeline treats the destination schema as externally managed.
+//
+//	CREATE TABLE data_pipe_resume_tokens (
+//	    pipeline_name TEXT PRIMARY KEY,
+//	    resume_token  BYTEA NOT NULL,
+//	    updated_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+//	);
+type PostgresStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewPostgresStore creates a resume.Store backed by table in db. If table is
+// empty, it defaults to "data_pipe_resume_tokens". table is spliced directly
+// into the store's query text, so it must be a bare SQL identifier; anything
+// else is rejected rather than risk building an injectable query.
+func NewPostgresStore(db *sql.DB, table string) (*PostgresStore, error) {
+	if table == "" {
+		table = "data_pipe_resume_tokens"
+	}
+	if !validIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("resume_store.table %q is not a valid identifier", table)
+	}
+	return &PostgresStore{db: db, table: table}, nil
+}
+
+// Load fetches the resume token for pipelineName, returning a nil token if
+// none has been saved yet.
+func (p *PostgresStore) Load(ctx context.Context, pipelineName string) ([]byte, error) {
+	query := fmt.Sprintf("SELECT resume_token FROM %s WHERE pipeline_name = $1", p.table)
+
+	var token []byte
+	err := p.db.QueryRowContext(ctx, query, pipelineName).Scan(&token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load resume token: %w", err)
+	}
+	return token, nil
+}
+
+// Save upserts the resume token for pipelineName.
+func (p *PostgresStore) Save(ctx context.Context, pipelineName string, token []byte) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (pipeline_name, resume_token, updated_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (pipeline_name)
+		DO UPDATE SET resume_token = EXCLUDED.resume_token, updated_at = now()`, p.table)
+
+	if _, err := p.db.ExecContext(ctx, query, pipelineName, token); err != nil {
+		return fmt.Errorf("failed to save resume token: %w", err)
+	}
+	return nil
+}
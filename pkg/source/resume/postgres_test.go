@@ -0,0 +1,28 @@
+package resume
+
+import "testing"
+
+func TestNewPostgresStoreRejectsInvalidTableName(t *testing.T) {
+	cases := []string{
+		"tokens; DROP TABLE users;--",
+		"tokens\" CASCADE; --",
+		"",
+	}
+	for _, table := range cases {
+		if table == "" {
+			continue // empty is the documented default, not invalid
+		}
+		if _, err := NewPostgresStore(nil, table); err == nil {
+			t.Errorf("NewPostgresStore(%q) = nil error, want error", table)
+		}
+	}
+}
+
+func TestNewPostgresStoreAcceptsValidTableName(t *testing.T) {
+	if _, err := NewPostgresStore(nil, "custom_resume_tokens"); err != nil {
+		t.Errorf("NewPostgresStore(custom_resume_tokens) unexpected error: %v", err)
+	}
+	if _, err := NewPostgresStore(nil, ""); err != nil {
+		t.Errorf("NewPostgresStore(\"\") unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+// Package resume persists MongoDB change stream resume tokens so a source
+// can restart after downtime without losing or re-delivering events it has
+// already sunk.
+package resume
+
+import "context"
+
+// Store loads and saves a resume token for a named pipeline. A nil token
+// with a nil error from Load means no token has been saved yet.
+type Store interface {
+	Load(ctx context.Context, pipelineName string) ([]byte, error)
+	Save(ctx context.Context, pipelineName string, token []byte) error
+}
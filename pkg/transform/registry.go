@@ -0,0 +1,36 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/IEatCodeDaily/data-pipe/pkg/config"
+	"github.com/IEatCodeDaily/data-pipe/pkg/pipeline"
+)
+
+func init() {
+	pipeline.RegisterTransformer("fieldmapper", newFieldMapperFromConfig)
+	pipeline.RegisterTransformer("passthrough", newPassThroughTransformerFromConfig)
+}
+
+func newFieldMapperFromConfig(cfg config.TransformerConfig) (pipeline.Transformer, error) {
+	if _, ok := cfg.Settings["mappings"]; !ok {
+		return nil, fmt.Errorf("fieldmapper transformer requires 'mappings' configuration")
+	}
+
+	settingsJSON, err := json.Marshal(cfg.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal transformer settings: %w", err)
+	}
+
+	var fmConfig FieldMapperConfig
+	if err := json.Unmarshal(settingsJSON, &fmConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse fieldmapper configuration: %w", err)
+	}
+
+	return NewFieldMapper(fmConfig)
+}
+
+func newPassThroughTransformerFromConfig(cfg config.TransformerConfig) (pipeline.Transformer, error) {
+	return NewPassThroughTransformer(), nil
+}